@@ -1,6 +1,8 @@
 package main
 
 import (
+	"bytes"
+	"compress/gzip"
 	"encoding/xml"
 	"flag"
 	"fmt"
@@ -21,6 +23,7 @@ import (
 
 const (
 	sitemapURL         = "https://docs.aws.amazon.com/sitemap_index.xml"
+	robotsTxtURL       = "https://docs.aws.amazon.com/robots.txt"
 	rateLimitDelay     = 2 * time.Second // Delay between each request to prevent rate limiting
 	maxBackoffAttempts = 5               // Maximum number of backoff attempts before giving up
 	sleepDuration      = 3 * time.Second // Time to sleep on rate limit detection or failure
@@ -109,6 +112,7 @@ func main() {
 	test := flag.Int("test", 0, "Specify the number of documents to download for testing")
 	logFile := flag.String("logfile", "", "Specify a file to write debug logs to")
 	maxWorkers := flag.Int("workers", 10, "Number of concurrent workers to download files")
+	seed := flag.String("seed", robotsTxtURL, "Seed URL to start crawling from (robots.txt, sitemap index, or single urlset)")
 	flag.BoolVar(&rateLimitEnabled, "rate-limit", false, "Enable rate limiting to avoid 403 errors")
 	flag.Parse()
 
@@ -145,10 +149,10 @@ func main() {
 		}()
 	}
 
-	// Fetch and parse the sitemap concurrently
+	// Fetch and parse the sitemap (or robots.txt) concurrently
 	go func() {
 		defer close(urlChannel) // Close the channel when done
-		err := fetchAndParseSitemap(sitemapURL, *test, urlChannel)
+		err := fetchFromSeed(*seed, *test, urlChannel)
 		if err != nil {
 			log.Fatalf("Error fetching sitemap: %v", err)
 		}
@@ -159,6 +163,69 @@ func main() {
 	log.Println("Scraping finished")
 }
 
+// fetchFromSeed dispatches to robots.txt discovery or direct sitemap parsing
+// depending on what the seed URL looks like.
+func fetchFromSeed(seedURL string, maxDocs int, urlChannel chan<- string) error {
+	if strings.HasSuffix(strings.ToLower(seedURL), "robots.txt") {
+		return fetchAndParseRobotsTxt(seedURL, maxDocs, urlChannel)
+	}
+	return fetchAndParseSitemap(seedURL, maxDocs, urlChannel)
+}
+
+// fetchAndParseRobotsTxt fetches robots.txt and enqueues every sitemap it
+// advertises via "Sitemap:" directives.
+func fetchAndParseRobotsTxt(robotsURL string, maxDocs int, urlChannel chan<- string) error {
+	log.Printf("Fetching robots.txt: %s", robotsURL)
+	resp, err := fetchWithRateLimitHandling(robotsURL)
+	if err != nil {
+		if robotsURL == robotsTxtURL {
+			log.Printf("Error fetching robots.txt %s, falling back to default sitemap: %v", robotsURL, err)
+			return fetchAndParseSitemap(sitemapURL, maxDocs, urlChannel)
+		}
+		log.Printf("Error fetching robots.txt %s: %v", robotsURL, err)
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var sitemaps []string
+	for _, line := range strings.Split(string(body), "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(strings.ToLower(line), "sitemap:") {
+			continue
+		}
+		loc := strings.TrimSpace(line[len("sitemap:"):])
+		if loc != "" {
+			sitemaps = append(sitemaps, loc)
+		}
+	}
+
+	if len(sitemaps) == 0 {
+		if robotsURL == robotsTxtURL {
+			log.Printf("No Sitemap directives found in robots.txt %s, falling back to default sitemap", robotsURL)
+			return fetchAndParseSitemap(sitemapURL, maxDocs, urlChannel)
+		}
+		return fmt.Errorf("no sitemap directives found in %s", robotsURL)
+	}
+
+	var failures int
+	for _, loc := range sitemaps {
+		log.Printf("Discovered sitemap via robots.txt: %s", loc)
+		if err := fetchAndParseSitemap(loc, maxDocs, urlChannel); err != nil {
+			log.Printf("Error fetching sitemap discovered via robots.txt: %v", err)
+			failures++
+		}
+	}
+	if failures == len(sitemaps) {
+		return fmt.Errorf("all %d sitemap(s) discovered via %s failed to fetch", failures, robotsURL)
+	}
+	return nil
+}
+
 // fetchAndParseSitemap fetches and parses a sitemap, handling both sitemap indexes and URL sets.
 func fetchAndParseSitemap(sitemapURL string, maxDocs int, urlChannel chan<- string) error {
 	// Replace http with https
@@ -190,8 +257,9 @@ func fetchAndParseSitemap(sitemapURL string, maxDocs int, urlChannel chan<- stri
 	}
 	defer resp.Body.Close()
 
-	body, err := ioutil.ReadAll(resp.Body)
+	body, err := readSitemapBody(sitemapURL, resp)
 	if err != nil {
+		log.Printf("Error reading sitemap body %s: %v", sitemapURL, err)
 		return err
 	}
 
@@ -252,6 +320,32 @@ func fetchAndParseSitemap(sitemapURL string, maxDocs int, urlChannel chan<- stri
 	return fmt.Errorf("unable to parse sitemap at %s", sitemapURL)
 }
 
+// readSitemapBody reads the response body, transparently decompressing it
+// when the URL, Content-Type, or Content-Encoding indicates gzip. AWS ships
+// some sitemap segments as .xml.gz, which the XML unmarshaler would
+// otherwise silently fail to parse.
+func readSitemapBody(sitemapURL string, resp *http.Response) ([]byte, error) {
+	gzipped := strings.HasSuffix(strings.ToLower(sitemapURL), ".gz") ||
+		strings.Contains(strings.ToLower(resp.Header.Get("Content-Encoding")), "gzip") ||
+		strings.Contains(strings.ToLower(resp.Header.Get("Content-Type")), "gzip")
+
+	if !gzipped {
+		return ioutil.ReadAll(resp.Body)
+	}
+
+	gzReader, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("creating gzip reader for %s: %w", sitemapURL, err)
+	}
+	defer gzReader.Close()
+
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, gzReader); err != nil {
+		return nil, fmt.Errorf("decompressing gzip sitemap %s: %w", sitemapURL, err)
+	}
+	return buf.Bytes(), nil
+}
+
 // fetchWithRateLimitHandling fetches the document from the given URL and handles 403 rate limiting or connection errors.
 func fetchWithRateLimitHandling(url string) (*http.Response, error) {
 	maxRetries := 5